@@ -0,0 +1,75 @@
+#!/usr/bin/env bash
+# Notification hook: shows a native desktop notification.
+# Uses osascript on macOS, notify-send on Linux, and a PowerShell toast on
+# Windows and WSL. Failures are ignored so the hook never disrupts Claude.
+set -euo pipefail
+
+INPUT=$(cat)
+
+MESSAGE=$(echo "$INPUT" | jq -r '.message // empty')
+TITLE=$(echo "$INPUT" | jq -r '.title // "Claude Code"')
+CWD=$(echo "$INPUT" | jq -r '.cwd // empty')
+
+if [[ -z "$MESSAGE" ]]; then
+  exit 0
+fi
+
+# Name the project so notifications from parallel sessions are distinct
+if [[ -n "$CWD" ]]; then
+  TITLE="${TITLE} — $(basename "$CWD")"
+fi
+
+notify_macos() {
+  # Pass text as arguments to avoid AppleScript string escaping.
+  osascript - "$TITLE" "$MESSAGE" << 'APPLESCRIPT'
+on run argv
+  display notification (item 2 of argv) with title (item 1 of argv)
+end run
+APPLESCRIPT
+}
+
+notify_linux() {
+  notify-send --app-name="Claude Code" "$TITLE" "$MESSAGE"
+}
+
+notify_windows() {
+  # Text is passed through the environment to avoid PowerShell quoting.
+  # WSL only shares variables named in WSLENV with Windows processes.
+  # shellcheck disable=SC2016
+  NOTIFY_TITLE="$TITLE" NOTIFY_MESSAGE="$MESSAGE" \
+    WSLENV="NOTIFY_TITLE:NOTIFY_MESSAGE${WSLENV:+:$WSLENV}" \
+    powershell.exe -NoProfile -NonInteractive -Command '
+      $m = [Windows.UI.Notifications.ToastNotificationManager,
+        Windows.UI.Notifications, ContentType = WindowsRuntime]
+      $x = $m::GetTemplateContent(
+        [Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+      $t = $x.GetElementsByTagName("text")
+      $t.Item(0).AppendChild($x.CreateTextNode($env:NOTIFY_TITLE)) > $null
+      $t.Item(1).AppendChild($x.CreateTextNode($env:NOTIFY_MESSAGE)) > $null
+      $id = "{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe"
+      $m::CreateToastNotifier($id).Show(
+        [Windows.UI.Notifications.ToastNotification]::new($x))
+    '
+}
+
+case "$(uname -s)" in
+  Darwin)
+    notify_macos > /dev/null 2>&1 || true
+    ;;
+  Linux)
+    # WSL often has notify-send installed but no notification daemon to
+    # show it, so fall back to Windows whenever notify-send is missing or
+    # fails.
+    if command -v notify-send > /dev/null 2>&1 &&
+      notify_linux > /dev/null 2>&1; then
+      :
+    elif command -v powershell.exe > /dev/null 2>&1; then
+      notify_windows > /dev/null 2>&1 || true
+    fi
+    ;;
+  MINGW* | MSYS* | CYGWIN*)
+    notify_windows > /dev/null 2>&1 || true
+    ;;
+esac
+
+exit 0
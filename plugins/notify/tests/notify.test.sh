@@ -0,0 +1,233 @@
+#!/usr/bin/env bash
+# Tests for the notify hook script.
+# Run: bash tests/notify.test.sh
+set -euo pipefail
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+HOOK="${SCRIPT_DIR}/../hooks/notify.sh"
+BASH_BIN="$(command -v bash)"
+
+TMP_DIR=$(mktemp -d)
+trap 'rm -rf "$TMP_DIR"' EXIT
+
+# The hook only sees stubbed notifiers plus the real tools it needs, so
+# tests behave the same regardless of what the host has installed.
+TOOLS_DIR="${TMP_DIR}/tools"
+mkdir -p "$TOOLS_DIR"
+for tool in basename cat jq; do
+  ln -s "$(command -v "$tool")" "${TOOLS_DIR}/${tool}"
+done
+
+STUB_LOG="${TMP_DIR}/calls.log"
+export STUB_LOG
+
+# Create a stub command in dir that logs its name, arguments, and the
+# NOTIFY_* and WSLENV environment to STUB_LOG. It exits with STUB_EXIT, or
+# 1 when its name is listed in the space-separated STUB_FAIL.
+make_stub() {
+  local dir="$1" name="$2"
+  mkdir -p "$dir"
+  cat > "${dir}/${name}" << STUB
+#!${BASH_BIN}
+{
+  echo "cmd: ${name}"
+  for arg in "\$@"; do
+    echo "arg: \$arg"
+  done
+  [[ -n "\${NOTIFY_TITLE:-}" ]] && echo "env title: \$NOTIFY_TITLE"
+  [[ -n "\${NOTIFY_MESSAGE:-}" ]] && echo "env message: \$NOTIFY_MESSAGE"
+  [[ -n "\${WSLENV:-}" ]] && echo "env WSLENV: \$WSLENV"
+  cat > /dev/null
+} >> "\$STUB_LOG"
+if [[ " \${STUB_FAIL:-} " == *" ${name} "* ]]; then
+  exit 1
+fi
+exit \${STUB_EXIT:-0}
+STUB
+  chmod +x "${dir}/${name}"
+}
+
+# Create a stub dir for a platform: a uname reporting os, plus the given
+# notifier stubs.
+make_platform() {
+  local os="$1"
+  shift
+  local dir
+  dir=$(mktemp -d "${TMP_DIR}/stubs.XXXXXX")
+  printf '#!%s\necho %s\n' "$BASH_BIN" "$os" > "${dir}/uname"
+  chmod +x "${dir}/uname"
+  local name
+  for name in "$@"; do
+    make_stub "$dir" "$name"
+  done
+  echo "$dir"
+}
+
+PASS=0
+FAIL=0
+
+# Run a test case. expect is a newline-separated list of lines that must
+# appear in the stub call log, or that must not when prefixed with "!", or
+# "--no-call" when no notifier should run.
+run_test() {
+  local desc="$1" stubs="$2" input="$3" expect="$4"
+
+  : > "$STUB_LOG"
+
+  local output exit_code
+  set +eo pipefail
+  output=$(echo "$input" |
+    PATH="${stubs}:${TOOLS_DIR}" "$BASH_BIN" "$HOOK" 2>&1)
+  exit_code=$?
+  set -eo pipefail
+
+  if [[ -n "$output" || "$exit_code" -ne 0 ]]; then
+    echo "  FAIL: ${desc}"
+    echo "    expected: (no output, exit 0)"
+    echo "    got output: ${output:-<empty>}"
+    echo "    got exit: ${exit_code}"
+    FAIL=$((FAIL + 1))
+    return
+  fi
+
+  local log
+  log=$(cat "$STUB_LOG")
+
+  if [[ "$expect" == "--no-call" ]]; then
+    if [[ -z "$log" ]]; then
+      echo "  PASS: ${desc}"
+      PASS=$((PASS + 1))
+    else
+      echo "  FAIL: ${desc}"
+      echo "    expected: no notifier calls"
+      echo "    got: ${log}"
+      FAIL=$((FAIL + 1))
+    fi
+    return
+  fi
+
+  local line
+  while IFS= read -r line; do
+    if [[ "$line" == !* ]]; then
+      if grep -qxF -- "${line#!}" "$STUB_LOG"; then
+        echo "  FAIL: ${desc}"
+        echo "    unexpected call log line: ${line#!}"
+        echo "    got: ${log}"
+        FAIL=$((FAIL + 1))
+        return
+      fi
+    elif ! grep -qxF -- "$line" "$STUB_LOG"; then
+      echo "  FAIL: ${desc}"
+      echo "    expected call log line: ${line}"
+      echo "    got: ${log:-<empty>}"
+      FAIL=$((FAIL + 1))
+      return
+    fi
+  done <<< "$expect"
+
+  echo "  PASS: ${desc}"
+  PASS=$((PASS + 1))
+}
+
+payload() {
+  jq -n --arg message "$1" --arg cwd "${2:-/work/myproj}" '{
+    session_id: "test",
+    transcript_path: "/dev/null",
+    hook_event_name: "Notification",
+    message: $message,
+    cwd: $cwd
+  }'
+}
+
+MACOS=$(make_platform Darwin osascript)
+LINUX=$(make_platform Linux notify-send)
+WSL=$(make_platform Linux powershell.exe)
+WSL_NOTIFY_SEND=$(make_platform Linux notify-send powershell.exe)
+LINUX_BARE=$(make_platform Linux)
+WINDOWS=$(make_platform MINGW64_NT-10.0 powershell.exe)
+UNKNOWN=$(make_platform Plan9 osascript notify-send powershell.exe)
+
+echo "notify hook tests"
+echo "================="
+echo ""
+
+echo "platform notifiers:"
+run_test "macOS uses osascript" \
+  "$MACOS" "$(payload "Claude needs your permission to use Bash")" \
+  $'cmd: osascript\narg: Claude Code — myproj\narg: Claude needs your permission to use Bash'
+
+run_test "Linux uses notify-send" \
+  "$LINUX" "$(payload "Claude is waiting for your input")" \
+  $'cmd: notify-send\narg: Claude Code — myproj\narg: Claude is waiting for your input'
+
+run_test "WSL falls back to PowerShell" \
+  "$WSL" "$(payload "Claude is waiting for your input")" \
+  $'cmd: powershell.exe\nenv title: Claude Code — myproj\nenv message: Claude is waiting for your input'
+
+run_test "WSL shares the text variables with PowerShell" \
+  "$WSL" "$(payload "Claude is waiting for your input")" \
+  "env WSLENV: NOTIFY_TITLE:NOTIFY_MESSAGE"
+
+export WSLENV="USERPROFILE/p"
+run_test "WSL keeps existing WSLENV entries" \
+  "$WSL" "$(payload "Claude is waiting for your input")" \
+  "env WSLENV: NOTIFY_TITLE:NOTIFY_MESSAGE:USERPROFILE/p"
+unset WSLENV
+
+run_test "Linux with both prefers notify-send" \
+  "$WSL_NOTIFY_SEND" "$(payload "Claude is waiting for your input")" \
+  $'cmd: notify-send\n!cmd: powershell.exe'
+
+export STUB_FAIL="notify-send"
+run_test "WSL falls back to PowerShell when notify-send fails" \
+  "$WSL_NOTIFY_SEND" "$(payload "Claude is waiting for your input")" \
+  $'cmd: notify-send\ncmd: powershell.exe\nenv message: Claude is waiting for your input'
+
+run_test "failing notify-send without PowerShell does nothing more" \
+  "$LINUX" "$(payload "Claude is waiting for your input")" \
+  $'cmd: notify-send\n!cmd: powershell.exe'
+unset STUB_FAIL
+
+run_test "Windows uses PowerShell" \
+  "$WINDOWS" "$(payload "Claude is waiting for your input")" \
+  $'cmd: powershell.exe\nenv message: Claude is waiting for your input'
+
+run_test "Linux without notifiers does nothing" \
+  "$LINUX_BARE" "$(payload "Claude is waiting for your input")" \
+  "--no-call"
+
+run_test "unknown platform does nothing" \
+  "$UNKNOWN" "$(payload "Claude is waiting for your input")" \
+  "--no-call"
+
+echo ""
+echo "payload handling:"
+run_test "quotes passed through untouched" \
+  "$MACOS" "$(payload "Run \"rm\" in 'tmp'?")" \
+  "arg: Run \"rm\" in 'tmp'?"
+
+run_test "payload title used" \
+  "$LINUX" "$(payload "hi" | jq '.title = "Permission needed"')" \
+  "arg: Permission needed — myproj"
+
+run_test "missing cwd leaves title bare" \
+  "$LINUX" "$(payload "hi" | jq 'del(.cwd)')" \
+  "arg: Claude Code"
+
+run_test "empty message does nothing" \
+  "$LINUX" "$(payload "")" \
+  "--no-call"
+
+export STUB_EXIT=1
+run_test "failing notifier still exits 0" \
+  "$LINUX" "$(payload "hi")" \
+  "cmd: notify-send"
+unset STUB_EXIT
+
+echo ""
+echo "================="
+echo "Results: ${PASS} passed, ${FAIL} failed"
+
+if [[ "$FAIL" -gt 0 ]]; then
+  exit 1
+fi
@@ -0,0 +1,130 @@
+#!/usr/bin/env bash
+# UserPromptSubmit hook: detects credentials in submitted prompts.
+# In "block" mode (default) the prompt is rejected with a reason. In "warn"
+# mode it goes through unchanged, so the secret stays in the transcript;
+# Claude is only told not to repeat or persist it.
+#
+# Config is read from ~/.claude/prompt-secrets.json and from
+# .claude/prompt-secrets.json in the session cwd:
+#   { "mode": "block", "patterns": [{ "name": "...", "regex": "..." }] }
+# Patterns from both files are added to the built-in ones below. The mode
+# comes from the user file; a project file can tighten it to "block" but
+# never relax it to "warn".
+set -euo pipefail
+
+DEFAULT_PATTERNS='[
+  { "name": "AWS access key ID", "regex": "\\b(AKIA|ASIA)[0-9A-Z]{16}\\b" },
+  { "name": "GitHub token", "regex": "\\bgh[pousr]_[A-Za-z0-9]{36,}" },
+  { "name": "GitHub token", "regex": "\\bgithub_pat_[A-Za-z0-9_]{22,}" },
+  { "name": "GitLab token", "regex": "\\bglpat-[A-Za-z0-9_-]{20,}" },
+  { "name": "Slack token", "regex": "\\bxox[abposr]-[A-Za-z0-9-]{10,}" },
+  { "name": "API secret key", "regex": "\\bsk-[A-Za-z0-9_-]{32,}" },
+  { "name": "Google API key", "regex": "\\bAIza[0-9A-Za-z_-]{35}" },
+  { "name": "Stripe live key", "regex": "\\b[rs]k_live_[0-9A-Za-z]{24,}" },
+  { "name": "private key", "regex": "-----BEGIN ([A-Z]+ )*PRIVATE KEY-----" }
+]'
+
+INPUT=$(cat)
+
+PROMPT=$(echo "$INPUT" | jq -r '.prompt // empty')
+CWD=$(echo "$INPUT" | jq -r '.cwd')
+
+if [[ -z "$PROMPT" ]]; then
+  exit 0
+fi
+
+# Print the config at path as compact JSON. Configs that are not valid
+# JSON, or not an object with an optional string mode and array patterns,
+# are ignored with a warning so they can never disable the built-ins.
+load_config() {
+  local path="$1" config
+  if ! config=$(
+    jq -ce '
+      select(type == "object"
+        and ((.mode // "") | type) == "string"
+        and ((.patterns // []) | type) == "array")
+    ' "$path" 2> /dev/null
+  ) || [[ -z "$config" ]]; then
+    echo "prompt-secrets: ignoring invalid config: ${path}" >&2
+    config='{}'
+  fi
+  echo "$config"
+}
+
+# Block without naming a pattern when the prompt could not be checked.
+block_unchecked() {
+  jq -n '{
+    "decision": "block",
+    "reason": ("Prompt blocked: prompt-secrets failed to check it for " +
+      "secrets. Check the hook config and submit again.")
+  }'
+  exit 0
+}
+
+USER_CONFIG='{}'
+if [[ -f "${HOME}/.claude/prompt-secrets.json" ]]; then
+  USER_CONFIG=$(load_config "${HOME}/.claude/prompt-secrets.json")
+fi
+
+PROJECT_CONFIG='{}'
+if [[ -f "${CWD}/.claude/prompt-secrets.json" ]]; then
+  PROJECT_CONFIG=$(load_config "${CWD}/.claude/prompt-secrets.json")
+fi
+
+# Block wins: warn only applies when the user asked for it and the project
+# does not ask for anything else. Patterns from both files are combined.
+CONFIG=$(
+  jq -cn \
+    --argjson user "$USER_CONFIG" \
+    --argjson project "$PROJECT_CONFIG" \
+    '{
+      mode: (if ($user.mode // "block") == "warn"
+        and ($project.mode // "warn") == "warn"
+        then "warn" else "block" end),
+      patterns: (($user.patterns // []) + ($project.patterns // []))
+    }'
+) || block_unchecked
+
+MODE=$(echo "$CONFIG" | jq -r '.mode') || MODE="block"
+
+# Names of matching patterns, deduplicated in pattern order. Malformed
+# pattern entries and invalid regexes are skipped rather than failing the
+# whole prompt. The prompt is read from stdin, as it can exceed the size
+# limit for a single command-line argument.
+MATCHES=$(
+  printf '%s' "$INPUT" | jq -r \
+    --argjson defaults "$DEFAULT_PATTERNS" \
+    --argjson config "$CONFIG" \
+    '.prompt as $prompt
+    | [
+      ($defaults + ($config.patterns // []))[]
+      | select(type == "object" and (.regex | type) == "string")
+      | select(.regex as $re | try ($prompt | test($re)) catch false)
+      | (.name // "unnamed pattern" | tostring)
+    ] | reduce .[] as $n ([]; if index([$n]) then . else . + [$n] end)
+    | join(", ")'
+) || block_unchecked
+
+if [[ -z "$MATCHES" ]]; then
+  exit 0
+fi
+
+case "$MODE" in
+  warn)
+    jq -n --arg found "$MATCHES" '{
+      "hookSpecificOutput": {
+        "hookEventName": "UserPromptSubmit",
+        "additionalContext": ("The user prompt appears to contain a secret (" +
+          $found + "). Do not repeat it, write it to files, include it in " +
+          "commands, or commit it. Refer to it only indirectly.")
+      }
+    }'
+    ;;
+  *)
+    jq -n --arg found "$MATCHES" '{
+      "decision": "block",
+      "reason": ("Prompt blocked: it appears to contain a secret (" +
+        $found + "). Remove the secret and submit again.")
+    }'
+    ;;
+esac
@@ -0,0 +1,132 @@
+#!/usr/bin/env bash
+# SessionStart hook: injects current project state as additional context.
+# SessionStart also fires after compaction (source "compact"), so pinned
+# context is re-injected into the compacted conversation. PreCompact hook
+# output cannot add context, so this is the only hook that can restore it.
+# Sources are configured with environment variables:
+#   SESSION_CONTEXT_SOURCES     comma-separated list of sources to include
+#                               (default: "git-status,git-log,context-file,
+#                               pinned-file")
+#   SESSION_CONTEXT_FILE        project-relative context file
+#                               (default: "CONTEXT.md")
+#   SESSION_CONTEXT_PINNED_FILE project-relative pinned context file
+#                               (default: ".claude/pinned.md")
+#   SESSION_CONTEXT_LOG_COUNT   number of recent commits (default: 5)
+set -euo pipefail
+
+# Maximum number of changed files listed in the git status section.
+MAX_STATUS_LINES=20
+
+# Maximum number of lines included from the context and pinned files.
+MAX_FILE_LINES=100
+
+INPUT=$(cat)
+
+CWD=$(echo "$INPUT" | jq -r '.cwd')
+SOURCE=$(echo "$INPUT" | jq -r '.source // "startup"')
+
+DEFAULT_SOURCES="git-status,git-log,context-file,pinned-file"
+SOURCES="${SESSION_CONTEXT_SOURCES:-$DEFAULT_SOURCES}"
+CONTEXT_FILE="${SESSION_CONTEXT_FILE:-CONTEXT.md}"
+PINNED_FILE="${SESSION_CONTEXT_PINNED_FILE:-.claude/pinned.md}"
+LOG_COUNT="${SESSION_CONTEXT_LOG_COUNT:-5}"
+
+CONTEXT=""
+
+# Append a titled section to CONTEXT. Empty bodies are skipped.
+add_section() {
+  local title="$1" body="$2"
+
+  if [[ -z "$body" ]]; then
+    return
+  fi
+
+  if [[ -n "$CONTEXT" ]]; then
+    CONTEXT+=$'\n\n'
+  fi
+  CONTEXT+="## ${title}"$'\n\n'"${body}"
+}
+
+# Wrap non-empty stdin in a fenced code block.
+fenced() {
+  local body
+  body=$(cat)
+  if [[ -n "$body" ]]; then
+    printf '```text\n%s\n```\n' "$body"
+  fi
+}
+
+git_status_summary() {
+  local status total
+  status=$(git -C "$CWD" status --short --branch 2> /dev/null) || return 0
+
+  total=$(printf '%s\n' "$status" | wc -l)
+  # First line is the branch header; the rest are changed files.
+  if [[ "$total" -gt $((MAX_STATUS_LINES + 1)) ]]; then
+    printf '%s\n' "$status" | head -n $((MAX_STATUS_LINES + 1))
+    echo "... and $((total - MAX_STATUS_LINES - 1)) more"
+  else
+    printf '%s\n' "$status"
+  fi
+}
+
+git_log_summary() {
+  git -C "$CWD" log --oneline --no-decorate -n "$LOG_COUNT" 2> /dev/null ||
+    true
+}
+
+# Print a project-relative file if it exists, capped at MAX_FILE_LINES.
+project_file() {
+  local path="${CWD}/$1" total
+  if [[ ! -f "$path" ]]; then
+    return 0
+  fi
+
+  total=$(awk 'END { print NR }' "$path")
+  if [[ "$total" -gt "$MAX_FILE_LINES" ]]; then
+    head -n "$MAX_FILE_LINES" "$path"
+    echo "... and $((total - MAX_FILE_LINES)) more lines in $1"
+  else
+    cat "$path"
+  fi
+}
+
+IFS=',' read -ra SOURCE_LIST <<< "$SOURCES"
+for name in "${SOURCE_LIST[@]}"; do
+  case "${name// /}" in
+    git-status)
+      add_section "Git status" "$(git_status_summary | fenced)"
+      ;;
+    git-log)
+      add_section "Recent commits" "$(git_log_summary | fenced)"
+      ;;
+    context-file)
+      add_section "$CONTEXT_FILE" "$(project_file "$CONTEXT_FILE")"
+      ;;
+    pinned-file)
+      add_section "Pinned context" "$(project_file "$PINNED_FILE")"
+      ;;
+    "") ;;
+    *)
+      echo "session-context: unknown source: ${name}" >&2
+      ;;
+  esac
+done
+
+# Nothing to inject
+if [[ -z "$CONTEXT" ]]; then
+  exit 0
+fi
+
+if [[ "$SOURCE" == "compact" ]]; then
+  HEADING="# Project context after compaction"
+else
+  HEADING="# Project context at session start"
+fi
+
+jq -n --arg ctx "${HEADING}"$'\n\n'"$CONTEXT" '{
+  "hookSpecificOutput": {
+    "hookEventName": "SessionStart",
+    "additionalContext": $ctx
+  }
+}'
@@ -0,0 +1,295 @@
+#!/usr/bin/env bash
+# Tests for the session-context hook script.
+# Run: bash tests/session-context.test.sh
+set -euo pipefail
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+HOOK="${SCRIPT_DIR}/../hooks/session-context.sh"
+
+TMP_DIR=$(mktemp -d)
+trap 'rm -rf "$TMP_DIR"' EXIT
+
+# Keep test repos independent of the user's git config.
+export GIT_CONFIG_GLOBAL=/dev/null
+export GIT_CONFIG_NOSYSTEM=1
+export GIT_AUTHOR_NAME=test GIT_AUTHOR_EMAIL=test@example.com
+export GIT_COMMITTER_NAME=test GIT_COMMITTER_EMAIL=test@example.com
+
+PASS=0
+FAIL=0
+
+# Create a git repo with the given commit subjects, one empty commit each.
+make_repo() {
+  local dir subject
+  dir=$(mktemp -d "${TMP_DIR}/repo.XXXXXX")
+  git -C "$dir" init -q -b main
+  for subject in "$@"; do
+    git -C "$dir" commit -q --allow-empty -m "$subject"
+  done
+  echo "$dir"
+}
+
+# Run a test case with optional VAR=value environment assignments before
+# the description. The SessionStart source is taken from EVENT_SOURCE
+# (default: "startup"). Expectations:
+#   "--none"        no output, exit 0
+#   "!text"         additionalContext must not contain text
+#   anything else   additionalContext must contain it
+run_test() {
+  local -a env_vars=()
+  while [[ "$1" == *=* ]]; do
+    env_vars+=("$1")
+    shift
+  done
+  local desc="$1" cwd="$2" expect="$3"
+
+  local input
+  input=$(jq -n \
+    --arg cwd "$cwd" \
+    --arg source "${EVENT_SOURCE:-startup}" \
+    '{
+      session_id: "test",
+      transcript_path: "/dev/null",
+      hook_event_name: "SessionStart",
+      source: $source,
+      cwd: $cwd
+    }')
+
+  local output exit_code
+  # Empty arrays are unbound under set -u on bash < 4.4 (macOS /bin/bash).
+  set +eo pipefail
+  output=$(
+    echo "$input" |
+      env ${env_vars[@]+"${env_vars[@]}"} bash "$HOOK" 2> /dev/null
+  )
+  exit_code=$?
+  set -eo pipefail
+
+  if [[ "$expect" == "--none" ]]; then
+    if [[ -z "$output" && "$exit_code" -eq 0 ]]; then
+      echo "  PASS: ${desc}"
+      PASS=$((PASS + 1))
+    else
+      echo "  FAIL: ${desc}"
+      echo "    expected: (no output, exit 0)"
+      echo "    got output: ${output:-<empty>}"
+      echo "    got exit: ${exit_code}"
+      FAIL=$((FAIL + 1))
+    fi
+    return
+  fi
+
+  local keys event_name context
+  if ! echo "$output" | jq empty 2>/dev/null || [[ -z "$output" ]]; then
+    echo "  FAIL: ${desc}"
+    echo "    expected: valid JSON"
+    echo "    got:      ${output:-<empty>}"
+    FAIL=$((FAIL + 1))
+    return
+  fi
+
+  # Verify hookSpecificOutput has exactly: additionalContext, hookEventName
+  keys=$(echo "$output" | jq -r \
+    '.hookSpecificOutput | keys | join(",")' 2>/dev/null)
+  if [[ "$keys" != "additionalContext,hookEventName" ]]; then
+    echo "  FAIL: ${desc}"
+    echo "    expected hookSpecificOutput keys:" \
+      "additionalContext,hookEventName"
+    echo "    got: ${keys}"
+    FAIL=$((FAIL + 1))
+    return
+  fi
+
+  event_name=$(echo "$output" | jq -r \
+    '.hookSpecificOutput.hookEventName' 2>/dev/null)
+  if [[ "$event_name" != "SessionStart" ]]; then
+    echo "  FAIL: ${desc}"
+    echo "    expected hookEventName: SessionStart"
+    echo "    got: ${event_name}"
+    FAIL=$((FAIL + 1))
+    return
+  fi
+
+  context=$(echo "$output" | jq -r \
+    '.hookSpecificOutput.additionalContext' 2>/dev/null)
+  if [[ "$expect" == !* ]]; then
+    if [[ "$context" != *"${expect#!}"* ]]; then
+      echo "  PASS: ${desc}"
+      PASS=$((PASS + 1))
+    else
+      echo "  FAIL: ${desc}"
+      echo "    expected context not to contain: ${expect#!}"
+      echo "    got: ${context}"
+      FAIL=$((FAIL + 1))
+    fi
+  elif [[ "$context" == *"$expect"* ]]; then
+    echo "  PASS: ${desc}"
+    PASS=$((PASS + 1))
+  else
+    echo "  FAIL: ${desc}"
+    echo "    expected context to contain: ${expect}"
+    echo "    got: ${context}"
+    FAIL=$((FAIL + 1))
+  fi
+}
+
+REPO=$(make_repo "first commit" "second commit" "third commit")
+touch "${REPO}/untracked.txt"
+printf 'Current focus: the parser rewrite.\n' > "${REPO}/CONTEXT.md"
+mkdir -p "${REPO}/docs"
+printf 'Alternate context.\n' > "${REPO}/docs/NOTES.md"
+mkdir -p "${REPO}/.claude"
+printf -- '- [ ] finish the tokenizer\n' > "${REPO}/.claude/pinned.md"
+printf 'Custom pin.\n' > "${REPO}/docs/PIN.md"
+
+BIG_REPO=$(make_repo "initial")
+for i in $(seq 1 25); do
+  touch "${BIG_REPO}/file-${i}.txt"
+done
+
+LONG_DIR=$(mktemp -d "${TMP_DIR}/long.XXXXXX")
+seq -f 'context line %g' 1 120 > "${LONG_DIR}/CONTEXT.md"
+mkdir -p "${LONG_DIR}/.claude"
+seq -f 'pinned line %g' 1 130 > "${LONG_DIR}/.claude/pinned.md"
+
+PLAIN_DIR=$(mktemp -d "${TMP_DIR}/plain.XXXXXX")
+
+PLAIN_CONTEXT_DIR=$(mktemp -d "${TMP_DIR}/plain.XXXXXX")
+printf 'Not a repo.\n' > "${PLAIN_CONTEXT_DIR}/CONTEXT.md"
+
+echo "session-context hook tests"
+echo "=========================="
+echo ""
+
+echo "default sources:"
+run_test "git status lists branch" \
+  "$REPO" \
+  "## main"
+
+run_test "git status lists changed files" \
+  "$REPO" \
+  "?? untracked.txt"
+
+run_test "recent commits listed" \
+  "$REPO" \
+  "third commit"
+
+run_test "context file contents included" \
+  "$REPO" \
+  "Current focus: the parser rewrite."
+
+run_test "context file section titled by name" \
+  "$REPO" \
+  "## CONTEXT.md"
+
+run_test "pinned file included" \
+  "$REPO" \
+  $'## Pinned context\n\n- [ ] finish the tokenizer'
+
+run_test "startup heading" \
+  "$REPO" \
+  "# Project context at session start"
+
+run_test "non-git dir without context file" \
+  "$PLAIN_DIR" \
+  "--none"
+
+run_test "non-git dir still includes context file" \
+  "$PLAIN_CONTEXT_DIR" \
+  "Not a repo."
+
+run_test "non-git dir has no git sections" \
+  "$PLAIN_CONTEXT_DIR" \
+  "!## Git status"
+
+echo ""
+echo "configuration:"
+run_test SESSION_CONTEXT_SOURCES=git-log \
+  "sources limit sections" \
+  "$REPO" \
+  "!## Git status"
+
+run_test "SESSION_CONTEXT_SOURCES=git-log, context-file" \
+  "sources tolerate spaces" \
+  "$REPO" \
+  "Current focus"
+
+run_test SESSION_CONTEXT_LOG_COUNT=1 \
+  "log count limits commits" \
+  "$REPO" \
+  "!second commit"
+
+run_test SESSION_CONTEXT_FILE=docs/NOTES.md \
+  "custom context file" \
+  "$REPO" \
+  "Alternate context."
+
+run_test SESSION_CONTEXT_SOURCES=pinned-file \
+  SESSION_CONTEXT_PINNED_FILE=docs/PIN.md \
+  "custom pinned file" \
+  "$REPO" \
+  "Custom pin."
+
+run_test SESSION_CONTEXT_SOURCES=context-file \
+  SESSION_CONTEXT_FILE=missing.md \
+  "missing context file produces nothing" \
+  "$REPO" \
+  "--none"
+
+run_test SESSION_CONTEXT_SOURCES=bogus \
+  "unknown source produces nothing" \
+  "$REPO" \
+  "--none"
+
+echo ""
+echo "after compaction:"
+EVENT_SOURCE=compact
+run_test "compaction heading" \
+  "$REPO" \
+  "# Project context after compaction"
+
+run_test "pinned file re-injected" \
+  "$REPO" \
+  "finish the tokenizer"
+
+run_test "compaction without sources produces nothing" \
+  "$PLAIN_DIR" \
+  "--none"
+unset EVENT_SOURCE
+
+echo ""
+echo "truncation:"
+run_test "long status is truncated" \
+  "$BIG_REPO" \
+  "... and 5 more"
+
+run_test SESSION_CONTEXT_SOURCES=git-status \
+  "truncated status omits trailing files" \
+  "$BIG_REPO" \
+  "!file-9.txt"
+
+run_test "long context file is truncated" \
+  "$LONG_DIR" \
+  $'context line 100\n... and 20 more lines in CONTEXT.md'
+
+run_test SESSION_CONTEXT_SOURCES=context-file \
+  "truncated context file omits trailing lines" \
+  "$LONG_DIR" \
+  "!context line 101"
+
+run_test "long pinned file is truncated" \
+  "$LONG_DIR" \
+  $'pinned line 100\n... and 30 more lines in .claude/pinned.md'
+
+run_test SESSION_CONTEXT_SOURCES=pinned-file \
+  "truncated pinned file omits trailing lines" \
+  "$LONG_DIR" \
+  "!pinned line 101"
+
+echo ""
+echo "=========================="
+echo "Results: ${PASS} passed, ${FAIL} failed"
+
+if [[ "$FAIL" -gt 0 ]]; then
+  exit 1
+fi
@@ -0,0 +1,131 @@
+#!/usr/bin/env bash
+# Stop hook: runs project gate commands and blocks stopping if any fail.
+# Gates are read one per line from the committed (HEAD) version of
+# .claude/stop-gates in the project dir ($CLAUDE_PROJECT_DIR, falling back
+# to the hook's cwd) and run from there. The agent can write to the working
+# tree, so uncommitted changes to the file are never run, and a modified or
+# deleted gates file blocks stopping instead.
+# Blank lines and lines starting with "#" are ignored.
+#
+# Gates are checked again on every stop, including while Claude continues
+# because of a previous block (stop_hook_active). After MAX_BLOCKS blocks in
+# a row Claude is let go with a warning, rather than looping forever on a
+# gate it cannot fix. The count is kept per session in STOP_GATES_STATE_DIR
+# (default: $TMPDIR/stop-gates-<uid>).
+set -euo pipefail
+
+# Number of trailing output lines to include per failed gate.
+MAX_LINES=40
+
+# Number of consecutive blocks before Claude is allowed to stop anyway.
+MAX_BLOCKS=3
+
+INPUT=$(cat)
+
+CWD=$(echo "$INPUT" | jq -r '.cwd')
+ACTIVE=$(echo "$INPUT" | jq -r '.stop_hook_active // false')
+SESSION_ID=$(echo "$INPUT" | jq -r '.session_id // empty')
+
+STATE_DIR="${STOP_GATES_STATE_DIR:-${TMPDIR:-/tmp}/stop-gates-$(id -u)}"
+SESSION_ID="${SESSION_ID//[^A-Za-z0-9_-]/}"
+STATE_FILE="${STATE_DIR}/${SESSION_ID:-default}"
+
+# A stop that is not a continuation starts a fresh count.
+if [[ "$ACTIVE" != "true" ]]; then
+  rm -f "$STATE_FILE"
+fi
+
+# Block stopping with reason, or let Claude stop with a warning once it has
+# been blocked MAX_BLOCKS times in a row. The reason goes to jq on stdin,
+# as gate output can exceed the size limit for a command-line argument.
+block() {
+  local reason="$1" blocks=0
+
+  if [[ -f "$STATE_FILE" ]]; then
+    blocks=$(cat "$STATE_FILE" 2> /dev/null) || blocks=0
+  fi
+  if [[ "$blocks" =~ ^[0-9]+$ ]]; then
+    blocks=$((10#$blocks))
+  else
+    blocks=0
+  fi
+
+  if [[ "$blocks" -ge "$MAX_BLOCKS" ]]; then
+    rm -f "$STATE_FILE"
+    echo "stop-gates: gates still failing after ${blocks} blocks" >&2
+    jq -n --arg blocks "$blocks" '{
+      "systemMessage": ("Stop gates are still failing after " + $blocks +
+        " attempts. Claude was allowed to stop; check the gates yourself.")
+    }'
+    exit 0
+  fi
+
+  (
+    umask 077
+    mkdir -p "$STATE_DIR" && echo "$((blocks + 1))" > "$STATE_FILE"
+  ) 2> /dev/null || true
+
+  printf '%s' "$reason" | jq -Rs '{
+    "decision": "block",
+    "reason": .
+  }'
+  exit 0
+}
+
+# The hook's cwd follows the agent's cd; gates belong to the project root.
+PROJECT_DIR="${CLAUDE_PROJECT_DIR:-$CWD}"
+
+GATES_PATH=".claude/stop-gates"
+
+# Only committed gates are trusted. Projects outside git, or without a
+# committed gates file, have no gates.
+if ! GATES=$(
+  git -C "$PROJECT_DIR" show "HEAD:./${GATES_PATH}" 2> /dev/null
+); then
+  if [[ -f "${PROJECT_DIR}/${GATES_PATH}" ]]; then
+    echo "stop-gates: ignoring uncommitted ${GATES_PATH}" >&2
+  fi
+  exit 0
+fi
+
+if ! git -C "$PROJECT_DIR" diff --quiet HEAD -- "$GATES_PATH" 2> /dev/null; then
+  REASON="${GATES_PATH} has uncommitted changes, so stop gates were not run."
+  REASON+=" Restore it with \`git checkout HEAD -- ${GATES_PATH}\` before"
+  REASON+=" finishing. Only the user should change stop gates."
+
+  block "$REASON"
+fi
+
+FAILURES=""
+
+while IFS= read -r gate || [[ -n "$gate" ]]; do
+  # Trim surrounding whitespace
+  gate="${gate#"${gate%%[![:space:]]*}"}"
+  gate="${gate%"${gate##*[![:space:]]}"}"
+
+  if [[ -z "$gate" || "$gate" == \#* ]]; then
+    continue
+  fi
+
+  # Gates must not read the gate list through the loop's stdin.
+  set +e
+  output=$(cd "$PROJECT_DIR" && bash -c "$gate" 2>&1 < /dev/null)
+  status=$?
+  set -e
+
+  if [[ "$status" -ne 0 ]]; then
+    FAILURES+="\$ ${gate} (exit ${status})"$'\n'
+    FAILURES+="$(printf '%s\n' "$output" | tail -n "$MAX_LINES")"$'\n\n'
+  fi
+done <<< "$GATES"
+
+# All gates passed (or none configured), allow Claude to stop
+if [[ -z "$FAILURES" ]]; then
+  rm -f "$STATE_FILE"
+  exit 0
+fi
+
+REASON="Stop gates failed. Fix the issues below before finishing."
+REASON+=$'\n\n'"${FAILURES%$'\n\n'}"
+
+block "$REASON"
@@ -0,0 +1,338 @@
+#!/usr/bin/env bash
+# Tests for the stop-gates hook script.
+# Run: bash tests/stop-gates.test.sh
+set -euo pipefail
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+HOOK="${SCRIPT_DIR}/../hooks/stop-gates.sh"
+
+TMP_DIR=$(mktemp -d)
+trap 'rm -rf "$TMP_DIR"' EXIT
+
+# Tests set CLAUDE_PROJECT_DIR explicitly where they need it.
+unset CLAUDE_PROJECT_DIR
+
+export STOP_GATES_STATE_DIR="${TMP_DIR}/state"
+
+# Keep test repos independent of the user's git config.
+export GIT_CONFIG_GLOBAL=/dev/null
+export GIT_CONFIG_NOSYSTEM=1
+export GIT_AUTHOR_NAME=test GIT_AUTHOR_EMAIL=test@example.com
+export GIT_COMMITTER_NAME=test GIT_COMMITTER_EMAIL=test@example.com
+
+PASS=0
+FAIL=0
+
+# Create a git repo whose committed .claude/stop-gates contains exactly
+# content. Pass no content to create a repo without a gates file.
+make_raw_project() {
+  local dir
+  dir=$(mktemp -d "${TMP_DIR}/project.XXXXXX")
+  git -C "$dir" init -q
+  if [[ "$#" -gt 0 ]]; then
+    mkdir -p "${dir}/.claude"
+    printf '%s' "$1" > "${dir}/.claude/stop-gates"
+    git -C "$dir" add .claude/stop-gates
+  fi
+  git -C "$dir" commit -q --allow-empty -m "initial"
+  echo "$dir"
+}
+
+# Create a git repo whose committed .claude/stop-gates contains the given
+# lines. Pass no lines to create a repo without a gates file.
+make_project() {
+  if [[ "$#" -eq 0 ]]; then
+    make_raw_project
+  else
+    make_raw_project "$(printf '%s\n' "$@")"
+  fi
+}
+
+# Run a test case. When the hook should block, expect is a substring the
+# reason must contain. When it should allow stopping, pass "--allow", or
+# "warn:<text>" when it should allow stopping with a systemMessage
+# containing text. Prefix the call with CLAUDE_PROJECT_DIR=... to set the
+# project dir.
+run_test() {
+  local desc="$1" cwd="$2" active="$3" expect="$4"
+
+  local input
+  input=$(jq -n \
+    --arg cwd "$cwd" \
+    --argjson active "$active" \
+    '{
+      session_id: "test",
+      transcript_path: "/dev/null",
+      hook_event_name: "Stop",
+      stop_hook_active: $active,
+      cwd: $cwd
+    }')
+
+  local output exit_code
+  set +eo pipefail
+  output=$(echo "$input" | bash "$HOOK" 2> /dev/null)
+  exit_code=$?
+  set -eo pipefail
+
+  if [[ "$expect" == "--allow" ]]; then
+    # Expect no output and exit 0
+    if [[ -z "$output" && "$exit_code" -eq 0 ]]; then
+      echo "  PASS: ${desc}"
+      PASS=$((PASS + 1))
+    else
+      echo "  FAIL: ${desc}"
+      echo "    expected: (no output, exit 0)"
+      echo "    got output: ${output:-<empty>}"
+      echo "    got exit: ${exit_code}"
+      FAIL=$((FAIL + 1))
+    fi
+    return
+  fi
+
+  if [[ "$expect" == warn:* ]]; then
+    if [[ "$(echo "$output" | jq -r 'keys | join(",")' 2>/dev/null)" \
+      == "systemMessage" && "$(echo "$output" | jq -r '.systemMessage')" \
+      == *"${expect#warn:}"* ]]; then
+      echo "  PASS: ${desc}"
+      PASS=$((PASS + 1))
+    else
+      echo "  FAIL: ${desc}"
+      echo "    expected: systemMessage containing ${expect#warn:}"
+      echo "    got output: ${output:-<empty>}"
+      FAIL=$((FAIL + 1))
+    fi
+    return
+  fi
+
+  local keys decision reason
+  if ! echo "$output" | jq empty 2>/dev/null; then
+    echo "  FAIL: ${desc}"
+    echo "    expected: valid JSON"
+    echo "    got:      ${output:-<empty>}"
+    FAIL=$((FAIL + 1))
+    return
+  fi
+
+  # Verify top-level has exactly: decision, reason
+  keys=$(echo "$output" | jq -r 'keys | join(",")' 2>/dev/null)
+  if [[ "$keys" != "decision,reason" ]]; then
+    echo "  FAIL: ${desc}"
+    echo "    expected top-level keys: decision,reason"
+    echo "    got: ${keys}"
+    FAIL=$((FAIL + 1))
+    return
+  fi
+
+  decision=$(echo "$output" | jq -r '.decision' 2>/dev/null)
+  if [[ "$decision" != "block" ]]; then
+    echo "  FAIL: ${desc}"
+    echo "    expected decision: block"
+    echo "    got: ${decision}"
+    FAIL=$((FAIL + 1))
+    return
+  fi
+
+  reason=$(echo "$output" | jq -r '.reason' 2>/dev/null)
+  if [[ "$reason" == *"$expect"* ]]; then
+    echo "  PASS: ${desc}"
+    PASS=$((PASS + 1))
+  else
+    echo "  FAIL: ${desc}"
+    echo "    expected reason to contain: ${expect}"
+    echo "    got: ${reason}"
+    FAIL=$((FAIL + 1))
+  fi
+}
+
+echo "stop-gates hook tests"
+echo "====================="
+echo ""
+
+echo "gates that should allow stopping:"
+run_test "no gates file" \
+  "$(make_project)" false \
+  "--allow"
+
+run_test "all gates pass" \
+  "$(make_project "true" "exit 0")" false \
+  "--allow"
+
+run_test "comments and blank lines ignored" \
+  "$(make_project "# exit 1" "" "   " "  true  ")" false \
+  "--allow"
+
+run_test "gate runs in cwd" \
+  "$(make_project "touch marker" "test -f marker")" false \
+  "--allow"
+
+run_test "last line without trailing newline" \
+  "$(make_raw_project 'true')" false \
+  "--allow"
+
+run_test "empty gates file" \
+  "$(make_raw_project '')" false \
+  "--allow"
+
+echo ""
+echo "project dir resolution:"
+SUBDIR_PROJECT=$(make_project "test -f root-marker || exit 7")
+touch "${SUBDIR_PROJECT}/root-marker"
+mkdir -p "${SUBDIR_PROJECT}/sub"
+
+CLAUDE_PROJECT_DIR="$SUBDIR_PROJECT" \
+  run_test "gates run from project dir" \
+  "${SUBDIR_PROJECT}/sub" false \
+  "--allow"
+
+FAILING_PROJECT=$(make_project "exit 4")
+mkdir -p "${FAILING_PROJECT}/sub"
+
+CLAUDE_PROJECT_DIR="$FAILING_PROJECT" \
+  run_test "gates found from subdirectory cwd" \
+  "${FAILING_PROJECT}/sub" false \
+  '$ exit 4 (exit 4)'
+
+run_test "cwd used without CLAUDE_PROJECT_DIR" \
+  "$FAILING_PROJECT" false \
+  '$ exit 4 (exit 4)'
+
+echo ""
+echo "gates that should block stopping:"
+run_test "failing gate names command and exit code" \
+  "$(make_project "exit 3")" false \
+  '$ exit 3 (exit 3)'
+
+run_test "failing gate includes output" \
+  "$(make_project "echo 'lint: unused variable x'; false")" false \
+  "lint: unused variable x"
+
+run_test "failing gate includes stderr" \
+  "$(make_project "echo 'boom' >&2; false")" false \
+  "boom"
+
+run_test "later gates still run after a failure" \
+  "$(make_project "false" "echo second-gate; false")" false \
+  "second-gate"
+
+run_test "long output keeps the tail" \
+  "$(make_project "seq 1 100; false")" false \
+  $'61\n62'
+
+run_test "unterminated last line still runs" \
+  "$(make_raw_project 'echo unterminated; false')" false \
+  "unterminated"
+
+run_test "output line over 128 KiB" \
+  "$(make_project "yes x | head -c 400000 | tr -d '\\n'; false")" false \
+  "xxxxxxxxxx"
+
+echo ""
+echo "repeated stops:"
+RETRY_PROJECT=$(make_project "exit 5")
+
+run_test "first stop blocks" \
+  "$RETRY_PROJECT" false \
+  '$ exit 5 (exit 5)'
+
+run_test "stop_hook_active still runs gates" \
+  "$RETRY_PROJECT" true \
+  '$ exit 5 (exit 5)'
+
+run_test "third block in a row" \
+  "$RETRY_PROJECT" true \
+  '$ exit 5 (exit 5)'
+
+run_test "stop allowed with warning after three blocks" \
+  "$RETRY_PROJECT" true \
+  "warn:still failing after 3 attempts"
+
+run_test "count restarts after giving up" \
+  "$RETRY_PROJECT" true \
+  '$ exit 5 (exit 5)'
+
+run_test "new stop restarts the count" \
+  "$RETRY_PROJECT" false \
+  '$ exit 5 (exit 5)'
+
+run_test "count survives into the next continuation" \
+  "$RETRY_PROJECT" true \
+  '$ exit 5 (exit 5)'
+
+FIXED_PROJECT=$(make_project "test -f fixed")
+run_test "gate failing before the fix" \
+  "$FIXED_PROJECT" false \
+  '$ test -f fixed (exit 1)'
+
+touch "${FIXED_PROJECT}/fixed"
+run_test "stop_hook_active allows once gates pass" \
+  "$FIXED_PROJECT" true \
+  "--allow"
+
+echo ""
+echo "only committed gates are trusted:"
+UNTRACKED_PROJECT=$(make_project)
+mkdir -p "${UNTRACKED_PROJECT}/.claude"
+printf 'touch ran-untracked\nexit 1\n' \
+  > "${UNTRACKED_PROJECT}/.claude/stop-gates"
+
+run_test "untracked gates file is not run" \
+  "$UNTRACKED_PROJECT" false \
+  "--allow"
+
+if [[ -e "${UNTRACKED_PROJECT}/ran-untracked" ]]; then
+  echo "  FAIL: untracked gate command was executed"
+  FAIL=$((FAIL + 1))
+else
+  echo "  PASS: untracked gate command was not executed"
+  PASS=$((PASS + 1))
+fi
+
+NON_GIT_PROJECT=$(mktemp -d "${TMP_DIR}/plain.XXXXXX")
+mkdir -p "${NON_GIT_PROJECT}/.claude"
+printf 'exit 1\n' > "${NON_GIT_PROJECT}/.claude/stop-gates"
+
+run_test "gates file outside git is not run" \
+  "$NON_GIT_PROJECT" false \
+  "--allow"
+
+EDITED_PROJECT=$(make_project "exit 1")
+printf 'touch ran-edited\n' > "${EDITED_PROJECT}/.claude/stop-gates"
+
+run_test "edited gates file blocks" \
+  "$EDITED_PROJECT" false \
+  "has uncommitted changes, so stop gates were not run"
+
+if [[ -e "${EDITED_PROJECT}/ran-edited" ]]; then
+  echo "  FAIL: edited gate command was executed"
+  FAIL=$((FAIL + 1))
+else
+  echo "  PASS: edited gate command was not executed"
+  PASS=$((PASS + 1))
+fi
+
+DELETED_PROJECT=$(make_project "exit 1")
+rm "${DELETED_PROJECT}/.claude/stop-gates"
+
+run_test "deleted gates file blocks" \
+  "$DELETED_PROJECT" false \
+  "git checkout HEAD -- .claude/stop-gates"
+
+STAGED_PROJECT=$(make_project "exit 1")
+printf 'true\n' > "${STAGED_PROJECT}/.claude/stop-gates"
+git -C "$STAGED_PROJECT" add .claude/stop-gates
+
+run_test "staged gates change blocks" \
+  "$STAGED_PROJECT" false \
+  "has uncommitted changes"
+
+run_test "stop_hook_active still blocks edited gates file" \
+  "$EDITED_PROJECT" true \
+  "has uncommitted changes"
+
+echo ""
+echo "====================="
+echo "Results: ${PASS} passed, ${FAIL} failed"
+
+if [[ "$FAIL" -gt 0 ]]; then
+  exit 1
+fi
@@ -1,7 +1,8 @@
 #!/usr/bin/env bash
-# PreToolUse hook: strips redundant cwd references from Bash git commands.
+# PreToolUse hook: strips redundant cwd references from Bash commands.
 # Handles two patterns Claude uses:
-#   1. "cd /current/dir && git ..." → "git ..."
+#   1. "cd /current/dir && make test" → "make test" (also ".", "$PWD",
+#      "~/dir", relative paths, and symlinked paths that resolve to the cwd)
 #   2. "git -C /current/dir status" → "git status"
 set -euo pipefail
 
@@ -24,19 +25,84 @@ ESCAPED_CWD=$(printf '%s\n' "$CWD" | sed 's/[.[\/*^$()+?{|\\]/\\&/g')
 
 UPDATED="$COMMAND"
 
-# Strip "cd <cwd> && git" or "cd <cwd>; git" prefix.
-# Only when the next command is git. Quoted and unquoted path forms,
-# with optional trailing slash.
-if [[ "$UPDATED" == cd\ * ]]; then
-  UPDATED=$(
-    printf '%s' "$UPDATED" | sed -E \
-      -e "s|^cd[[:space:]]+\"${ESCAPED_CWD}/?\"[[:space:]]*&&[[:space:]]*(git[[:space:]])|\1|" \
-      -e "s|^cd[[:space:]]+'${ESCAPED_CWD}/?'[[:space:]]*&&[[:space:]]*(git[[:space:]])|\1|" \
-      -e "s|^cd[[:space:]]+${ESCAPED_CWD}/?[[:space:]]*&&[[:space:]]*(git[[:space:]])|\1|" \
-      -e "s|^cd[[:space:]]+\"${ESCAPED_CWD}/?\"[[:space:]]*;[[:space:]]*(git[[:space:]])|\1|" \
-      -e "s|^cd[[:space:]]+'${ESCAPED_CWD}/?'[[:space:]]*;[[:space:]]*(git[[:space:]])|\1|" \
-      -e "s|^cd[[:space:]]+${ESCAPED_CWD}/?[[:space:]]*;[[:space:]]*(git[[:space:]])|\1|"
-  )
+# Leading "cd <dir> && " or "cd <dir>; " prefix. <dir> is captured as a
+# double-quoted, single-quoted, or bare word. Words containing characters
+# that need further shell parsing are not matched.
+CD_DQ='"([^"`\\]*)"'
+CD_SQ="'([^']*)'"
+CD_BARE='([^][:space:]"'"'"'`\;&|()<>*?]+)'
+CD_RE="^cd[[:space:]]+(${CD_DQ}|${CD_SQ}|${CD_BARE})"
+CD_RE+="[[:space:]]*(&&|;)[[:space:]]*([^[:space:]].*)\$"
+
+# Print the directory a leading cd argument names. style is "dq", "sq", or
+# "bare". Fails when knowing the directory needs shell expansion beyond
+# $PWD and a leading ~, so those commands are left alone.
+cd_target() {
+  local arg="$1" style="$2"
+
+  if [[ "$style" != "sq" ]]; then
+    # shellcheck disable=SC2016
+    case "$arg" in
+      '$PWD' | '${PWD}' | '$PWD/' | '${PWD}/')
+        printf '%s\n' "$CWD"
+        return
+        ;;
+      *'$'*) return 1 ;;
+    esac
+  fi
+
+  if [[ "$style" == "bare" ]]; then
+    case "$arg" in
+      -* | *[{}]*) return 1 ;;
+      '~') arg="$HOME" ;;
+      '~/'*) arg="${HOME}/${arg#'~/'}" ;;
+      '~'*) return 1 ;;
+    esac
+  fi
+
+  if [[ -z "$arg" ]]; then
+    return 1
+  fi
+  printf '%s\n' "$arg"
+}
+
+# Succeed if cd-ing to dir from the cwd would land in the cwd.
+is_cwd() {
+  local dir="$1" real_cwd real_dir
+
+  case "$dir" in
+    . | ./) return 0 ;;
+  esac
+
+  if [[ "$dir" != /* ]]; then
+    dir="${CWD}/${dir}"
+  fi
+  if [[ "${dir%/}" == "$CWD" ]]; then
+    return 0
+  fi
+
+  # Compare physical paths, resolving dir the way the command's own cd
+  # would: ".." is applied logically, then symlinks are followed.
+  real_cwd=$(CDPATH='' cd -- "$CWD" 2> /dev/null && pwd -P) || return 1
+  real_dir=$(CDPATH='' cd -- "$dir" 2> /dev/null && pwd -P) || return 1
+  [[ "$real_dir" == "$real_cwd" ]]
+}
+
+# Strip a leading cd prefix before any command when <dir> is the cwd,
+# whether written literally, as ".", "$PWD", "~/...", a relative path, or
+# a path reaching the cwd through symlinks. A command must follow; a bare
+# "cd <cwd>" is left alone.
+if [[ "$UPDATED" =~ $CD_RE ]]; then
+  case "${BASH_REMATCH[1]}" in
+    \"*) cd_style="dq" cd_arg="${BASH_REMATCH[2]}" ;;
+    \'*) cd_style="sq" cd_arg="${BASH_REMATCH[3]}" ;;
+    *) cd_style="bare" cd_arg="${BASH_REMATCH[4]}" ;;
+  esac
+  cd_rest="${BASH_REMATCH[6]}"
+
+  if cd_dir=$(cd_target "$cd_arg" "$cd_style") && is_cwd "$cd_dir"; then
+    UPDATED="$cd_rest"
+  fi
 fi
 
 # Strip git -C <cwd> flags from anywhere in the command.
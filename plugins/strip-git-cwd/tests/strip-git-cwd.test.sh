@@ -116,6 +116,14 @@ run_test() {
   fi
 }
 
+# Real directories for cases that resolve paths on disk:
+#   $TMP/real/proj, $TMP/link -> $TMP/real, $TMP/home/proj -> $TMP/real/proj
+TMP=$(cd "$(mktemp -d)" && pwd -P)
+trap 'rm -rf "$TMP"' EXIT
+mkdir -p "${TMP}/real/proj/sub" "${TMP}/home"
+ln -s "${TMP}/real" "${TMP}/link"
+ln -s "${TMP}/real/proj" "${TMP}/home/proj"
+
 echo "strip-git-cwd hook tests"
 echo "========================"
 echo ""
@@ -149,9 +157,29 @@ run_test "cd ; git with spaces" \
   "cd /foo/bar ; git status" "/foo/bar" \
   "git status"
 
-run_test "cd && non-git command (unchanged)" \
+run_test "cd && non-git command" \
   "cd /foo/bar && ls -la" "/foo/bar" \
-  "--unchanged"
+  "ls -la"
+
+run_test "cd && make" \
+  "cd /foo/bar && make test" "/foo/bar" \
+  "make test"
+
+run_test "cd ; non-git command" \
+  "cd /foo/bar; npm test" "/foo/bar" \
+  "npm test"
+
+run_test "cd double-quoted && non-git command" \
+  'cd "/foo/bar" && go test ./...' "/foo/bar" \
+  "go test ./..."
+
+run_test "cd && chained commands" \
+  "cd /foo/bar && make build && make test" "/foo/bar" \
+  "make build && make test"
+
+run_test "cd && cd subdir (keeps subdir cd)" \
+  "cd /foo/bar && cd sub && ls" "/foo/bar" \
+  "cd sub && ls"
 
 run_test "cd with dots in path" \
   "cd /foo/bar.baz && git log" "/foo/bar.baz" \
@@ -161,6 +189,44 @@ run_test "cd && git -C (both patterns)" \
   "cd /foo/bar && git -C /foo/bar status" "/foo/bar" \
   "git status"
 
+echo ""
+echo "cd prefix — targets that resolve to cwd:"
+run_test "cd . && ls" \
+  "cd . && ls" "/foo/bar" \
+  "ls"
+
+run_test "cd ./ && ls" \
+  "cd ./ && ls" "/foo/bar" \
+  "ls"
+
+run_test "cd \"\$PWD\" && ls" \
+  'cd "$PWD" && ls' "/foo/bar" \
+  "ls"
+
+run_test "cd \${PWD} && ls" \
+  'cd ${PWD} && ls' "/foo/bar" \
+  "ls"
+
+HOME="${TMP}/home" run_test "cd ~/proj (symlink to cwd) && ls" \
+  "cd ~/proj && ls" "${TMP}/real/proj" \
+  "ls"
+
+run_test "cd real path when cwd is a symlink" \
+  "cd ${TMP}/real/proj && ls" "${TMP}/link/proj" \
+  "ls"
+
+run_test "cd symlinked path when cwd is the real path" \
+  "cd ${TMP}/link/proj && ls" "${TMP}/real/proj" \
+  "ls"
+
+run_test "cd relative path back to cwd" \
+  "cd ../proj && ls" "${TMP}/real/proj" \
+  "ls"
+
+run_test "cd quoted relative path back to cwd" \
+  "cd 'sub/..' && ls" "${TMP}/real/proj" \
+  "ls"
+
 echo ""
 echo "cd prefix — commands that should NOT be stripped:"
 run_test "cd to different path" \
@@ -171,6 +237,46 @@ run_test "cd alone (no && or ;)" \
   "cd /foo/bar" "/foo/bar" \
   "--unchanged"
 
+run_test "cd && with nothing after" \
+  "cd /foo/bar &&" "/foo/bar" \
+  "--unchanged"
+
+run_test "cd to subdirectory of cwd" \
+  "cd /foo/bar/baz && ls" "/foo/bar" \
+  "--unchanged"
+
+run_test "cd to cwd prefix sibling" \
+  "cd /foo/bar2 && ls" "/foo/bar" \
+  "--unchanged"
+
+run_test "cd piped (not a prefix)" \
+  "cd /foo/bar | ls" "/foo/bar" \
+  "--unchanged"
+
+run_test "cd relative subdirectory" \
+  "cd sub && ls" "${TMP}/real/proj" \
+  "--unchanged"
+
+run_test "cd to another variable" \
+  'cd "$OTHER" && ls' "/foo/bar" \
+  "--unchanged"
+
+run_test "cd single-quoted \$PWD (literal)" \
+  "cd '\$PWD' && ls" "/foo/bar" \
+  "--unchanged"
+
+run_test "cd with option" \
+  "cd -P ${TMP}/real/proj && ls" "${TMP}/real/proj" \
+  "--unchanged"
+
+run_test "cd ~user" \
+  "cd ~nobody && ls" "/foo/bar" \
+  "--unchanged"
+
+run_test "cd to missing directory" \
+  "cd /foo/baz/../bar && ls" "/foo/bar" \
+  "--unchanged"
+
 echo ""
 echo "-C flag — commands that should be stripped:"
 run_test "basic -C <path>" \
@@ -0,0 +1,86 @@
+#!/usr/bin/env bash
+# PreToolUse/PostToolUse hook: appends one JSON line per tool call to an
+# audit log. PreToolUse only records the start time, keyed by tool_use_id,
+# so PostToolUse can log the call's duration.
+# The log defaults to ~/.claude/tool-audit.jsonl and can be moved with
+# TOOL_AUDIT_LOG. Start times are kept in TOOL_AUDIT_STATE_DIR (default:
+# $TMPDIR/tool-audit-<uid>). Audit only: the hook never prints a decision,
+# and failures are ignored so it never disrupts Claude.
+set -euo pipefail
+
+LOG="${TOOL_AUDIT_LOG:-${HOME}/.claude/tool-audit.jsonl}"
+STATE_DIR="${TOOL_AUDIT_STATE_DIR:-${TMPDIR:-/tmp}/tool-audit-$(id -u)}"
+
+# Start times older than this are from calls that never completed.
+STALE_MINUTES=1440
+
+# Commands can contain secrets, so the log and state are private.
+umask 077
+
+INPUT=$(cat)
+
+EVENT=$(echo "$INPUT" | jq -r '.hook_event_name // empty' 2> /dev/null) ||
+  exit 0
+TOOL_USE_ID=$(echo "$INPUT" | jq -r '.tool_use_id // empty' 2> /dev/null) ||
+  exit 0
+TOOL_USE_ID="${TOOL_USE_ID//[^A-Za-z0-9_-]/}"
+
+# Milliseconds since the epoch; jq's clock is portable and sub-second.
+now_ms() {
+  jq -n 'now * 1000 | floor'
+}
+
+if [[ "$EVENT" == "PreToolUse" ]]; then
+  if [[ -n "$TOOL_USE_ID" ]]; then
+    {
+      mkdir -p "$STATE_DIR" &&
+        now_ms > "${STATE_DIR}/${TOOL_USE_ID}"
+      find "$STATE_DIR" -type f -mmin +"$STALE_MINUTES" -delete
+    } 2> /dev/null || true
+  fi
+  exit 0
+fi
+
+DURATION_MS=""
+START_FILE="${STATE_DIR}/${TOOL_USE_ID}"
+if [[ -n "$TOOL_USE_ID" && -f "$START_FILE" ]]; then
+  START_MS=$(cat "$START_FILE" 2> /dev/null) || START_MS=""
+  rm -f "$START_FILE"
+  if [[ "$START_MS" =~ ^[0-9]+$ ]]; then
+    DURATION_MS=$(($(now_ms) - START_MS))
+  fi
+fi
+
+# Keep the fields useful for building allowlists: the Bash command, or the
+# path a file tool touched. File contents and tool output are left out.
+# The payload has no exit code, so status only says whether the call was
+# interrupted.
+ENTRY=$(echo "$INPUT" | jq -c --arg duration "$DURATION_MS" '
+  (.tool_input // {}) as $in
+  | (.tool_response // {}) as $res
+  | {
+      time: (now | todate),
+      session_id,
+      cwd,
+      tool: .tool_name,
+      command: (if .tool_name == "Bash" then $in.command else null end),
+      path: ($in.file_path // $in.notebook_path // $in.path),
+      duration_ms: (
+        if $duration == "" then null else ($duration | tonumber) end
+      ),
+      status: (
+        if ($res | type) == "object" and $res.interrupted == true
+        then "interrupted"
+        else "completed"
+        end
+      )
+    }
+  | with_entries(select(.value != null))
+' 2> /dev/null) || exit 0
+
+{
+  mkdir -p "$(dirname "$LOG")" &&
+    printf '%s\n' "$ENTRY" >> "$LOG"
+} 2> /dev/null || true
+
+exit 0
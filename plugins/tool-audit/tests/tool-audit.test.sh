@@ -0,0 +1,220 @@
+#!/usr/bin/env bash
+# Tests for the tool-audit hook script.
+# Run: bash tests/tool-audit.test.sh
+set -euo pipefail
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+HOOK="${SCRIPT_DIR}/../hooks/tool-audit.sh"
+
+TMP_DIR=$(mktemp -d)
+trap 'rm -rf "$TMP_DIR"' EXIT
+
+export TOOL_AUDIT_LOG="${TMP_DIR}/logs/audit.jsonl"
+export TOOL_AUDIT_STATE_DIR="${TMP_DIR}/state"
+
+PASS=0
+FAIL=0
+
+# Run a test case against a fresh log. filter is a jq expression evaluated
+# against the array of logged entries, which must produce true.
+run_test() {
+  local desc="$1" input="$2" filter="$3"
+
+  rm -f "$TOOL_AUDIT_LOG"
+
+  local output exit_code
+  set +eo pipefail
+  output=$(echo "$input" | bash "$HOOK" 2>&1)
+  exit_code=$?
+  set -eo pipefail
+
+  if [[ -n "$output" || "$exit_code" -ne 0 ]]; then
+    echo "  FAIL: ${desc}"
+    echo "    expected: (no output, exit 0)"
+    echo "    got output: ${output:-<empty>}"
+    echo "    got exit: ${exit_code}"
+    FAIL=$((FAIL + 1))
+    return
+  fi
+
+  local log=""
+  if [[ -f "$TOOL_AUDIT_LOG" ]]; then
+    log=$(cat "$TOOL_AUDIT_LOG")
+  fi
+
+  if jq -se "$filter" <<< "$log" > /dev/null 2>&1; then
+    echo "  PASS: ${desc}"
+    PASS=$((PASS + 1))
+  else
+    echo "  FAIL: ${desc}"
+    echo "    expected log matching: ${filter}"
+    echo "    got: ${log:-<empty>}"
+    FAIL=$((FAIL + 1))
+  fi
+}
+
+# PostToolUse payload. The tool_use_id is taken from TOOL_USE_ID (default:
+# "toolu_test").
+payload() {
+  local tool="$1" tool_input="$2" tool_response="${3:-{\}}"
+  jq -n \
+    --arg tool "$tool" \
+    --arg id "${TOOL_USE_ID:-toolu_test}" \
+    --argjson input "$tool_input" \
+    --argjson response "$tool_response" \
+    '{
+      session_id: "test",
+      transcript_path: "/dev/null",
+      cwd: "/work/myproj",
+      hook_event_name: "PostToolUse",
+      tool_name: $tool,
+      tool_input: $input,
+      tool_use_id: $id,
+      tool_response: $response
+    }'
+}
+
+# Run the PreToolUse side of a call, which must print nothing.
+run_pre() {
+  local output
+  output=$(
+    payload "$1" "$2" |
+      jq '.hook_event_name = "PreToolUse" | del(.tool_response)' |
+      bash "$HOOK" 2>&1
+  )
+  if [[ -n "$output" ]]; then
+    echo "  FAIL: PreToolUse printed output: ${output}"
+    FAIL=$((FAIL + 1))
+  fi
+}
+
+echo "tool-audit hook tests"
+echo "====================="
+echo ""
+
+echo "recorded fields:"
+run_test "Bash command recorded" \
+  "$(payload Bash '{"command":"make test"}' \
+    '{"stdout":"ok","stderr":"","interrupted":false}')" \
+  'length == 1 and .[0].tool == "Bash" and .[0].command == "make test"
+    and .[0].status == "completed" and .[0].cwd == "/work/myproj"
+    and .[0].session_id == "test"'
+
+run_test "timestamp recorded" \
+  "$(payload Bash '{"command":"ls"}')" \
+  '.[0].time | test("^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9:]{8}Z$")'
+
+run_test "file tool records path, not content" \
+  "$(payload Write '{"file_path":"/work/myproj/a.txt","content":"secret"}' \
+    '{"type":"create"}')" \
+  '.[0].path == "/work/myproj/a.txt" and (.[0] | has("command") | not)
+    and (tostring | contains("secret") | not)'
+
+run_test "tool output not recorded" \
+  "$(payload Bash '{"command":"cat .env"}' '{"stdout":"TOKEN=abc"}')" \
+  'tostring | contains("TOKEN") | not'
+
+run_test "non-Bash command input not recorded as command" \
+  "$(payload mcp__srv__run '{"command":"drop table"}')" \
+  '.[0].tool == "mcp__srv__run" and (.[0] | has("command") | not)'
+
+echo ""
+echo "status:"
+run_test "interrupted Bash call" \
+  "$(payload Bash '{"command":"sleep 100"}' '{"interrupted":true}')" \
+  '.[0].status == "interrupted"'
+
+run_test "string response" \
+  "$(payload Grep '{"pattern":"x"}' '"3 matches"')" \
+  '.[0].status == "completed"'
+
+echo ""
+echo "duration:"
+run_pre Bash '{"command":"make test"}'
+run_test "duration recorded after PreToolUse" \
+  "$(payload Bash '{"command":"make test"}')" \
+  '(.[0].duration_ms | type) == "number" and .[0].duration_ms >= 0'
+
+if [[ -e "${TOOL_AUDIT_STATE_DIR}/toolu_test" ]]; then
+  echo "  FAIL: start time removed after PostToolUse"
+  FAIL=$((FAIL + 1))
+else
+  echo "  PASS: start time removed after PostToolUse"
+  PASS=$((PASS + 1))
+fi
+
+START_MS=$(jq -n 'now * 1000 | floor - 5000')
+echo "$START_MS" > "${TOOL_AUDIT_STATE_DIR}/toolu_test"
+run_test "duration measured from stored start time" \
+  "$(payload Bash '{"command":"sleep 5"}')" \
+  '.[0].duration_ms >= 5000 and .[0].duration_ms < 60000'
+
+run_test "no duration without PreToolUse" \
+  "$(payload Bash '{"command":"ls"}')" \
+  '.[0] | has("duration_ms") | not'
+
+TOOL_USE_ID=toolu_other run_pre Bash '{"command":"ls"}'
+run_test "durations keyed by tool_use_id" \
+  "$(payload Bash '{"command":"ls"}')" \
+  '.[0] | has("duration_ms") | not'
+
+rm -f "$TOOL_AUDIT_LOG"
+run_pre Bash '{"command":"ls"}'
+if [[ ! -e "$TOOL_AUDIT_LOG" ]]; then
+  echo "  PASS: PreToolUse writes no log entry"
+  PASS=$((PASS + 1))
+else
+  echo "  FAIL: PreToolUse writes no log entry"
+  echo "    got: $(cat "$TOOL_AUDIT_LOG")"
+  FAIL=$((FAIL + 1))
+fi
+rm -f "${TOOL_AUDIT_STATE_DIR}/toolu_test"
+
+echo ""
+echo "log handling:"
+TOOL_AUDIT_LOG="${TMP_DIR}/new/dir/audit.jsonl"
+run_test "log directory created" \
+  "$(payload Bash '{"command":"ls"}')" \
+  'length == 1'
+TOOL_AUDIT_LOG="${TMP_DIR}/logs/audit.jsonl"
+
+rm -f "$TOOL_AUDIT_LOG"
+payload Bash '{"command":"one"}' | bash "$HOOK"
+if payload Bash '{"command":"two"}' | bash "$HOOK" &&
+  jq -se 'map(.command) == ["one", "two"]' "$TOOL_AUDIT_LOG" > /dev/null; then
+  echo "  PASS: entries appended"
+  PASS=$((PASS + 1))
+else
+  echo "  FAIL: entries appended"
+  echo "    got: $(cat "$TOOL_AUDIT_LOG")"
+  FAIL=$((FAIL + 1))
+fi
+
+payload Bash '{"command":"ls"}' | bash "$HOOK"
+if [[ "$(ls -l "$TOOL_AUDIT_LOG" | cut -c 1-10)" == "-rw-------" ]]; then
+  echo "  PASS: log is private"
+  PASS=$((PASS + 1))
+else
+  echo "  FAIL: log is private"
+  echo "    got: $(ls -l "$TOOL_AUDIT_LOG")"
+  FAIL=$((FAIL + 1))
+fi
+
+run_test "invalid JSON input ignored" \
+  "not json" \
+  'length == 0'
+
+TOOL_AUDIT_LOG="${TMP_DIR}/file/audit.jsonl"
+touch "${TMP_DIR}/file"
+run_test "unwritable log still exits 0" \
+  "$(payload Bash '{"command":"ls"}')" \
+  'length == 0'
+TOOL_AUDIT_LOG="${TMP_DIR}/logs/audit.jsonl"
+
+echo ""
+echo "====================="
+echo "Results: ${PASS} passed, ${FAIL} failed"
+
+if [[ "$FAIL" -gt 0 ]]; then
+  exit 1
+fi